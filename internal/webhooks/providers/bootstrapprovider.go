@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-operator/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+type BootstrapProviderWebhook struct {
+}
+
+func (r *BootstrapProviderWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		WithValidator(r).
+		For(&v1alpha1.BootstrapProvider{}).
+		Complete()
+}
+
+var _ webhook.CustomValidator = &BootstrapProviderWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *BootstrapProviderWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	provider, ok := obj.(*v1alpha1.BootstrapProvider)
+	if !ok {
+		return fmt.Errorf("expected a BootstrapProvider but got %T", obj)
+	}
+
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec")
+
+	if err := validateProviderVersion(fldPath.Child("version"), provider.Spec.Version); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, validateProviderFetchConfig(fldPath.Child("fetchConfig"), provider.Spec.FetchConfig)...)
+
+	return aggregateProviderErrors(v1alpha1.GroupVersion.WithKind("BootstrapProvider").GroupKind(), provider.Name, errs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *BootstrapProviderWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldProvider, ok := oldObj.(*v1alpha1.BootstrapProvider)
+	if !ok {
+		return fmt.Errorf("expected a BootstrapProvider but got %T", oldObj)
+	}
+
+	newProvider, ok := newObj.(*v1alpha1.BootstrapProvider)
+	if !ok {
+		return fmt.Errorf("expected a BootstrapProvider but got %T", newObj)
+	}
+
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec")
+
+	if err := validateProviderVersion(fldPath.Child("version"), newProvider.Spec.Version); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, validateProviderFetchConfig(fldPath.Child("fetchConfig"), newProvider.Spec.FetchConfig)...)
+
+	if err := validateProviderNameImmutable(fldPath.Child("providerName"), oldProvider.Spec.ProviderName, newProvider.Spec.ProviderName); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validateProviderVersionNotDowngraded(fldPath.Child("version"), oldProvider.Spec.Version, newProvider.Spec.Version); err != nil {
+		errs = append(errs, err)
+	}
+
+	// spec.deployment, spec.manager and spec.configSecret are allowed to change freely.
+
+	return aggregateProviderErrors(v1alpha1.GroupVersion.WithKind("BootstrapProvider").GroupKind(), newProvider.Name, errs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (r *BootstrapProviderWebhook) ValidateDelete(_ context.Context, obj runtime.Object) error {
+	return errors.New("deletion of bootstrap provider is not allowed")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package providers contains the validation webhooks for the cluster-api-operator
+// provider CRDs (CoreProvider, InfrastructureProvider, BootstrapProvider and
+// ControlPlaneProvider).
+package providers
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AddToManager registers all provider validation webhooks with the manager. This is the single
+// place the validators for the provider CRDs are wired up from; no defaulting or conversion
+// webhooks are registered.
+func AddToManager(mgr ctrl.Manager) error {
+	if err := (&CoreProviderWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for CoreProvider: %w", err)
+	}
+
+	if err := (&InfrastructureProviderWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for InfrastructureProvider: %w", err)
+	}
+
+	if err := (&BootstrapProviderWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for BootstrapProvider: %w", err)
+	}
+
+	if err := (&ControlPlaneProviderWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for ControlPlaneProvider: %w", err)
+	}
+
+	return nil
+}
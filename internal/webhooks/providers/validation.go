@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-operator/api/v1alpha1"
+)
+
+// validateProviderVersion validates that, if set, spec.version parses as a valid semantic version.
+func validateProviderVersion(fldPath *field.Path, version string) *field.Error {
+	if version == "" {
+		return nil
+	}
+
+	if _, err := semver.ParseTolerant(version); err != nil {
+		return field.Invalid(fldPath, version, fmt.Sprintf("must be a valid semantic version: %v", err))
+	}
+
+	return nil
+}
+
+// validateProviderFetchConfig validates that exactly one of URL or Selector is set on the FetchConfiguration.
+func validateProviderFetchConfig(fldPath *field.Path, fetchConfig *v1alpha1.FetchConfiguration) field.ErrorList {
+	var errs field.ErrorList
+
+	if fetchConfig == nil {
+		return errs
+	}
+
+	hasURL := fetchConfig.URL != ""
+	hasSelector := fetchConfig.Selector != nil
+
+	switch {
+	case hasURL && hasSelector:
+		errs = append(errs, field.Invalid(fldPath, fetchConfig, "exactly one of url or selector must be set, not both"))
+	case !hasURL && !hasSelector:
+		errs = append(errs, field.Invalid(fldPath, fetchConfig, "exactly one of url or selector must be set"))
+	}
+
+	return errs
+}
+
+// validateProviderNameImmutable validates that spec.providerName has not changed between the old and new provider.
+func validateProviderNameImmutable(fldPath *field.Path, oldName, newName string) *field.Error {
+	if oldName != newName {
+		return field.Forbidden(fldPath, "providerName is immutable once the provider is bound to a namespace")
+	}
+
+	return nil
+}
+
+// validateProviderVersionNotDowngraded validates that spec.version has not been downgraded between the
+// old and new provider. Invalid versions are ignored here, as validateProviderVersion already reports them.
+func validateProviderVersionNotDowngraded(fldPath *field.Path, oldVersion, newVersion string) *field.Error {
+	if oldVersion == "" || newVersion == "" {
+		return nil
+	}
+
+	oldSemver, err := semver.ParseTolerant(oldVersion)
+	if err != nil {
+		return nil
+	}
+
+	newSemver, err := semver.ParseTolerant(newVersion)
+	if err != nil {
+		return nil
+	}
+
+	if newSemver.LT(oldSemver) {
+		return field.Invalid(fldPath, newVersion, fmt.Sprintf("version cannot be downgraded from %s to %s", oldVersion, newVersion))
+	}
+
+	return nil
+}
+
+// aggregateProviderErrors turns a non-empty field.ErrorList into an apierrors.NewInvalid error so that
+// kubectl surfaces per-field problems. It returns nil when errs is empty.
+func aggregateProviderErrors(gk schema.GroupKind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(gk, name, errs)
+}
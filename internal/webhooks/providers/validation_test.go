@@ -0,0 +1,126 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api-operator/api/v1alpha1"
+)
+
+func TestProviders(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "providers Suite")
+}
+
+var _ = Describe("validateProviderVersion", func() {
+	fldPath := field.NewPath("spec", "version")
+
+	DescribeTable("validating spec.version",
+		func(version string, expectErr bool) {
+			err := validateProviderVersion(fldPath, version)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("empty version is valid", "", false),
+		Entry("a valid semantic version", "1.2.3", false),
+		Entry("a valid semantic version with a leading v", "v1.2.3", false),
+		Entry("an invalid version", "not-a-version", true),
+	)
+})
+
+var _ = Describe("validateProviderFetchConfig", func() {
+	fldPath := field.NewPath("spec", "fetchConfig")
+
+	DescribeTable("validating spec.fetchConfig",
+		func(fetchConfig *v1alpha1.FetchConfiguration, expectErrs int) {
+			errs := validateProviderFetchConfig(fldPath, fetchConfig)
+			Expect(errs).To(HaveLen(expectErrs))
+		},
+		Entry("nil fetchConfig is valid", nil, 0),
+		Entry("only url set is valid", &v1alpha1.FetchConfiguration{URL: "https://example.com"}, 0),
+		Entry("only selector set is valid", &v1alpha1.FetchConfiguration{Selector: &metav1.LabelSelector{}}, 0),
+		Entry("neither url nor selector set is invalid", &v1alpha1.FetchConfiguration{}, 1),
+		Entry("both url and selector set is invalid", &v1alpha1.FetchConfiguration{
+			URL:      "https://example.com",
+			Selector: &metav1.LabelSelector{},
+		}, 1),
+	)
+})
+
+var _ = Describe("validateProviderNameImmutable", func() {
+	fldPath := field.NewPath("spec", "providerName")
+
+	DescribeTable("validating spec.providerName immutability",
+		func(oldName, newName string, expectErr bool) {
+			err := validateProviderNameImmutable(fldPath, oldName, newName)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("unchanged name is valid", "aws", "aws", false),
+		Entry("changed name is invalid", "aws", "azure", true),
+	)
+})
+
+var _ = Describe("validateProviderVersionNotDowngraded", func() {
+	fldPath := field.NewPath("spec", "version")
+
+	DescribeTable("validating spec.version is not downgraded",
+		func(oldVersion, newVersion string, expectErr bool) {
+			err := validateProviderVersionNotDowngraded(fldPath, oldVersion, newVersion)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("old version empty is valid", "", "1.0.0", false),
+		Entry("new version empty is valid", "1.0.0", "", false),
+		Entry("unchanged version is valid", "1.0.0", "1.0.0", false),
+		Entry("upgraded version is valid", "1.0.0", "1.1.0", false),
+		Entry("downgraded version is invalid", "1.1.0", "1.0.0", true),
+		Entry("invalid old version is ignored", "not-a-version", "1.0.0", false),
+		Entry("invalid new version is ignored", "1.0.0", "not-a-version", false),
+	)
+})
+
+var _ = Describe("aggregateProviderErrors", func() {
+	gk := schema.GroupKind{Group: v1alpha1.GroupVersion.Group, Kind: "CoreProvider"}
+
+	It("returns nil for an empty error list", func() {
+		Expect(aggregateProviderErrors(gk, "my-provider", nil)).NotTo(HaveOccurred())
+	})
+
+	It("returns an aggregated error for a non-empty error list", func() {
+		errs := field.ErrorList{field.Invalid(field.NewPath("spec", "version"), "bad", "must be a valid semantic version")}
+
+		err := aggregateProviderErrors(gk, "my-provider", errs)
+		Expect(err).To(HaveOccurred())
+	})
+})
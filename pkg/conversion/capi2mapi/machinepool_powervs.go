@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi2mapi
+
+import (
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capibmv1 "sigs.k8s.io/cluster-api-provider-ibmcloud/api/v1beta2"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+
+	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+)
+
+var errCAPIMachinePoolPowerVSMachineTemplatePowerVSClusterCannotBeNil = errors.New("provided MachinePool, IBMPowerVSMachineTemplate and IBMPowerVSCluster can not be nil")
+
+// machinePoolAndPowerVSMachineTemplateAndPowerVSCluster stores the details of a Cluster API
+// experimental MachinePool and PowerVSMachineTemplate and PowerVSCluster.
+//
+// OpenShift MAPI has no MachinePool concept, so this converts into a MAPI MachineSet,
+// fanning the MachinePool's replicas into the MachineSet equivalent. Fields with no
+// MachineSet equivalent, such as Version, are dropped with a warning.
+type machinePoolAndPowerVSMachineTemplateAndPowerVSCluster struct {
+	machinePool    *expv1.MachinePool
+	template       *capibmv1.IBMPowerVSMachineTemplate
+	powerVSCluster *capibmv1.IBMPowerVSCluster
+	*machineAndPowerVSMachineAndPowerVSCluster
+}
+
+// FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster wraps a CAPI experimental MachinePool and
+// CAPIBM PowerVSMachineTemplate and CAPIBM PowerVSCluster into a capi2mapi MachineSetAndMachineTemplate.
+func FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster(mp *expv1.MachinePool, mts *capibmv1.IBMPowerVSMachineTemplate, pc *capibmv1.IBMPowerVSCluster, opts ...PowerVSMachineConversionOption) MachineSetAndMachineTemplate {
+	machineAndPowerVSMachine := &machineAndPowerVSMachineAndPowerVSCluster{
+		machine: &capiv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      mp.Spec.Template.ObjectMeta.Labels,
+				Annotations: mp.Spec.Template.ObjectMeta.Annotations,
+			},
+			Spec: mp.Spec.Template.Spec,
+		},
+		powerVSMachine: &capibmv1.IBMPowerVSMachine{
+			Spec: mts.Spec.Template.Spec,
+		},
+		powerVSCluster:        pc,
+		credentialsSecretName: defaultPowerVSCredentialsSecretName,
+	}
+
+	for _, opt := range opts {
+		opt(machineAndPowerVSMachine)
+	}
+
+	return machinePoolAndPowerVSMachineTemplateAndPowerVSCluster{
+		machinePool:    mp,
+		template:       mts,
+		powerVSCluster: pc,
+		machineAndPowerVSMachineAndPowerVSCluster: machineAndPowerVSMachine,
+	}
+}
+
+// ToMachineSet converts a capi2mapi machinePoolAndPowerVSMachineTemplateAndPowerVSCluster into a MAPI MachineSet.
+func (m machinePoolAndPowerVSMachineTemplateAndPowerVSCluster) ToMachineSet() (*mapiv1beta1.MachineSet, []string, error) {
+	if m.machinePool == nil || m.template == nil || m.powerVSCluster == nil || m.machineAndPowerVSMachineAndPowerVSCluster == nil {
+		return nil, nil, errCAPIMachinePoolPowerVSMachineTemplatePowerVSClusterCannotBeNil
+	}
+
+	var (
+		errs     []error
+		warnings []string
+	)
+
+	// Run the full ToMachine conversion so that we can check for
+	// any Machine level conversion errors in the spec translation.
+	mapiPowerVSMachine, warn, err := m.ToMachine()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	warnings = append(warnings, warn...)
+
+	mapiMachineSet := &mapiv1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.machinePool.Name,
+			Namespace: m.machinePool.Namespace,
+			Labels:    m.machinePool.Labels,
+		},
+		Spec: mapiv1beta1.MachineSetSpec{
+			Replicas: m.machinePool.Spec.Replicas,
+		},
+	}
+
+	mapiMachineSet.Spec.Template.Spec = mapiPowerVSMachine.Spec
+	mapiMachineSet.Spec.Template.ObjectMeta.Annotations = mapiPowerVSMachine.ObjectMeta.Annotations
+	mapiMachineSet.Spec.Template.ObjectMeta.Labels = mapiPowerVSMachine.ObjectMeta.Labels
+
+	if len(m.machinePool.Spec.FailureDomains) > 0 {
+		warnings = append(warnings, "cannot convert FailureDomains, MAPI MachineSets do not support multiple failure domains per MachineSet")
+	}
+
+	if len(m.machinePool.Spec.ProviderIDList) > 0 {
+		warnings = append(warnings, "cannot convert ProviderIDList, MAPI MachineSets track provider IDs per-Machine rather than on the MachineSet itself")
+	}
+
+	if m.machinePool.Spec.Template.Spec.Version != nil {
+		warnings = append(warnings, "cannot convert Version, MAPI MachineSets have no Kubernetes version field")
+	}
+
+	if len(errs) > 0 {
+		return nil, warnings, utilerrors.NewAggregate(errs)
+	}
+
+	return mapiMachineSet, warnings, nil
+}
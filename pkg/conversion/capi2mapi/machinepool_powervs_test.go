@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi2mapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capibmv1 "sigs.k8s.io/cluster-api-provider-ibmcloud/api/v1beta2"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/capi2mapi"
+)
+
+func newPowerVSMachinePool() (*expv1.MachinePool, *capibmv1.IBMPowerVSMachineTemplate, *capibmv1.IBMPowerVSCluster) {
+	machinePool := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "powervs-machinepool"},
+		Spec: expv1.MachinePoolSpec{
+			Replicas: ptr.To(int32(3)),
+			Template: capiv1.MachineTemplateSpec{
+				Spec: capiv1.MachineSpec{
+					Bootstrap: capiv1.Bootstrap{
+						DataSecretName: ptr.To("my-bootstrap-secret"),
+					},
+				},
+			},
+		},
+	}
+
+	template := &capibmv1.IBMPowerVSMachineTemplate{
+		Spec: capibmv1.IBMPowerVSMachineTemplateSpec{
+			Template: capibmv1.IBMPowerVSMachineTemplateResource{
+				Spec: capibmv1.IBMPowerVSMachineSpec{
+					ServiceInstanceID: "crn:v1:bluemix:service-instance",
+					Network: capibmv1.IBMPowerVSResourceReference{
+						ID: ptr.To("network-id"),
+					},
+					Image: &capibmv1.IBMPowerVSResourceReference{
+						Name: ptr.To("image-name"),
+					},
+				},
+			},
+		},
+	}
+
+	powerVSCluster := &capibmv1.IBMPowerVSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "powervs-cluster"},
+	}
+
+	return machinePool, template, powerVSCluster
+}
+
+var _ = Describe("PowerVS MachinePool conversion", func() {
+	It("does not warn when FailureDomains, ProviderIDList and Version are unset", func() {
+		machinePool, template, powerVSCluster := newPowerVSMachinePool()
+
+		_, warnings, err := capi2mapi.FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster(machinePool, template, powerVSCluster).ToMachineSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("warns when FailureDomains cannot be converted", func() {
+		machinePool, template, powerVSCluster := newPowerVSMachinePool()
+		machinePool.Spec.FailureDomains = []string{"domain-1", "domain-2"}
+
+		_, warnings, err := capi2mapi.FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster(machinePool, template, powerVSCluster).ToMachineSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("cannot convert FailureDomains")))
+	})
+
+	It("warns when ProviderIDList cannot be converted", func() {
+		machinePool, template, powerVSCluster := newPowerVSMachinePool()
+		machinePool.Spec.ProviderIDList = []string{"powervs://my-id"}
+
+		_, warnings, err := capi2mapi.FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster(machinePool, template, powerVSCluster).ToMachineSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("cannot convert ProviderIDList")))
+	})
+
+	It("warns when Version cannot be converted", func() {
+		machinePool, template, powerVSCluster := newPowerVSMachinePool()
+		machinePool.Spec.Template.Spec.Version = ptr.To("v1.28.0")
+
+		_, warnings, err := capi2mapi.FromMachinePoolAndPowerVSMachineTemplateAndPowerVSCluster(machinePool, template, powerVSCluster).ToMachineSet()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("cannot convert Version")))
+	})
+})
@@ -17,14 +17,12 @@ limitations under the License.
 package capi2mapi
 
 import (
-	"encoding/json"
 	"errors"
-	"fmt"
+
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 	capibmv1 "sigs.k8s.io/cluster-api-provider-ibmcloud/api/v1beta2"
@@ -32,6 +30,8 @@ import (
 
 	mapiv1 "github.com/openshift/api/machine/v1"
 	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/internal/conversion"
 )
 
 var (
@@ -39,11 +39,17 @@ var (
 	errCAPIMachineSetPowerVSMachineTemplatePowerVSClusterCannotBeNil = errors.New("provided MachineSet, IBMPowerVSMachineTemplate and IBMPowerVSCluster can not be nil")
 )
 
+// defaultPowerVSCredentialsSecretName is the well-known Secret name used to populate
+// CredentialsSecret on the generated MAPI PowerVSMachineProviderConfig when the caller
+// doesn't override it via WithCredentialsSecretName.
+const defaultPowerVSCredentialsSecretName = "powervs-credentials"
+
 // machineAndPowerVSMachineAndPowerVSCluster stores the details of a Cluster API Machine and PowerVSMachine and PowerVSCluster.
 type machineAndPowerVSMachineAndPowerVSCluster struct {
-	machine        *capiv1.Machine
-	powerVSMachine *capibmv1.IBMPowerVSMachine
-	powerVSCluster *capibmv1.IBMPowerVSCluster
+	machine               *capiv1.Machine
+	powerVSMachine        *capibmv1.IBMPowerVSMachine
+	powerVSCluster        *capibmv1.IBMPowerVSCluster
+	credentialsSecretName string
 }
 
 // machineSetAndPowerVSMachineTemplateAndPowerVSCluster stores the details of a Cluster API MachineSet and PowerVSMachineTemplate and AWSCluster.
@@ -54,30 +60,59 @@ type machineSetAndPowerVSMachineTemplateAndPowerVSCluster struct {
 	*machineAndPowerVSMachineAndPowerVSCluster
 }
 
+// PowerVSMachineConversionOption customizes the behaviour of the PowerVS Machine conversion.
+type PowerVSMachineConversionOption func(*machineAndPowerVSMachineAndPowerVSCluster)
+
+// WithCredentialsSecretName overrides the default well-known CredentialsSecret name
+// populated on the generated MAPI PowerVSMachineProviderConfig.
+func WithCredentialsSecretName(name string) PowerVSMachineConversionOption {
+	return func(m *machineAndPowerVSMachineAndPowerVSCluster) {
+		m.credentialsSecretName = name
+	}
+}
+
 // FromMachineAndPowerVSMachineAndPowerVSCluster wraps a CAPI Machine and CAPIBM PowerVSMachine and CAPIBM PowerVSCluster into a capi2mapi MachineAndInfrastructureMachine.
-func FromMachineAndPowerVSMachineAndPowerVSCluster(m *capiv1.Machine, pm *capibmv1.IBMPowerVSMachine, pc *capibmv1.IBMPowerVSCluster) MachineAndInfrastructureMachine {
-	return &machineAndPowerVSMachineAndPowerVSCluster{machine: m, powerVSMachine: pm, powerVSCluster: pc}
+func FromMachineAndPowerVSMachineAndPowerVSCluster(m *capiv1.Machine, pm *capibmv1.IBMPowerVSMachine, pc *capibmv1.IBMPowerVSCluster, opts ...PowerVSMachineConversionOption) MachineAndInfrastructureMachine {
+	wrapped := &machineAndPowerVSMachineAndPowerVSCluster{
+		machine:               m,
+		powerVSMachine:        pm,
+		powerVSCluster:        pc,
+		credentialsSecretName: defaultPowerVSCredentialsSecretName,
+	}
+
+	for _, opt := range opts {
+		opt(wrapped)
+	}
+
+	return wrapped
 }
 
 // FromMachineSetAndPowerVSMachineTemplateAndPowerVSCluster wraps a CAPI MachineSet and CAPIBM PowerVSMachineTemplate and CAPIBM PowerVSCluster into a capi2mapi MachineSetAndAWSMachineTemplateAndAWSCluster.
-func FromMachineSetAndPowerVSMachineTemplateAndPowerVSCluster(ms *capiv1.MachineSet, mts *capibmv1.IBMPowerVSMachineTemplate, pc *capibmv1.IBMPowerVSCluster) MachineSetAndMachineTemplate {
+func FromMachineSetAndPowerVSMachineTemplateAndPowerVSCluster(ms *capiv1.MachineSet, mts *capibmv1.IBMPowerVSMachineTemplate, pc *capibmv1.IBMPowerVSCluster, opts ...PowerVSMachineConversionOption) MachineSetAndMachineTemplate {
+	machineAndPowerVSMachine := &machineAndPowerVSMachineAndPowerVSCluster{
+		machine: &capiv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      ms.Spec.Template.ObjectMeta.Labels,
+				Annotations: ms.Spec.Template.ObjectMeta.Annotations,
+			},
+			Spec: ms.Spec.Template.Spec,
+		},
+		powerVSMachine: &capibmv1.IBMPowerVSMachine{
+			Spec: mts.Spec.Template.Spec,
+		},
+		powerVSCluster:        pc,
+		credentialsSecretName: defaultPowerVSCredentialsSecretName,
+	}
+
+	for _, opt := range opts {
+		opt(machineAndPowerVSMachine)
+	}
+
 	return machineSetAndPowerVSMachineTemplateAndPowerVSCluster{
 		machineSet:     ms,
 		template:       mts,
 		powerVSCluster: pc,
-		machineAndPowerVSMachineAndPowerVSCluster: &machineAndPowerVSMachineAndPowerVSCluster{
-			machine: &capiv1.Machine{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels:      ms.Spec.Template.ObjectMeta.Labels,
-					Annotations: ms.Spec.Template.ObjectMeta.Annotations,
-				},
-				Spec: ms.Spec.Template.Spec,
-			},
-			powerVSMachine: &capibmv1.IBMPowerVSMachine{
-				Spec: mts.Spec.Template.Spec,
-			},
-			powerVSCluster: pc,
-		},
+		machineAndPowerVSMachineAndPowerVSCluster: machineAndPowerVSMachine,
 	}
 }
 
@@ -104,7 +139,7 @@ func (m machineAndPowerVSMachineAndPowerVSCluster) ToMachine() (*mapiv1beta1.Mac
 		errors = append(errors, err)
 	}
 
-	powerVSRawExt, err := rawExtensionFromPowerVSProviderSpec(mapiPowerVSSpec)
+	powerVSRawExt, err := conversion.RawExtensionFromProviderSpec(mapiPowerVSSpec)
 	if err != nil {
 		errors = append(errors, err)
 	}
@@ -183,6 +218,14 @@ func (m machineAndPowerVSMachineAndPowerVSCluster) toProviderSpec() (*mapiv1.Pow
 		errors = append(errors, err)
 	}
 
+	loadBalancers, warn := m.convertLoadBalancersToMAPI()
+	warnings = append(warnings, warn...)
+
+	credentialsSecretName := m.credentialsSecretName
+	if credentialsSecretName == "" {
+		credentialsSecretName = defaultPowerVSCredentialsSecretName
+	}
+
 	mapiProviderConfig := mapiv1.PowerVSMachineProviderConfig{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "PowerVSMachineProviderConfig",
@@ -196,8 +239,10 @@ func (m machineAndPowerVSMachineAndPowerVSCluster) toProviderSpec() (*mapiv1.Pow
 		ProcessorType:   mapiv1.PowerVSProcessorType(m.powerVSMachine.Spec.ProcessorType),
 		Processors:      m.powerVSMachine.Spec.Processors,
 		MemoryGiB:       m.powerVSMachine.Spec.MemoryGiB,
-		//CredentialsSecret:
-		//LoadBalancers: TODO: Not supported for workers.
+		CredentialsSecret: &mapiv1.PowerVSSecretReference{
+			Name: credentialsSecretName,
+		},
+		LoadBalancers: loadBalancers,
 	}
 
 	userDataSecretName := ptr.Deref(m.machine.Spec.Bootstrap.DataSecretName, "")
@@ -210,25 +255,43 @@ func (m machineAndPowerVSMachineAndPowerVSCluster) toProviderSpec() (*mapiv1.Pow
 	return &mapiProviderConfig, warnings, nil
 }
 
-// Conversion helpers.
+// isControlPlaneMachine returns true if the wrapped CAPI Machine is labelled as a control plane Machine.
+func (m machineAndPowerVSMachineAndPowerVSCluster) isControlPlaneMachine() bool {
+	_, ok := m.machine.Labels[capiv1.MachineControlPlaneLabel]
+	return ok
+}
 
-// TODO: May be we can use generics and support for all the platforms?
-// rawExtensionFromPowerVSProviderSpec marshals the machine provider spec.
-func rawExtensionFromPowerVSProviderSpec(spec *mapiv1.PowerVSMachineProviderConfig) (*runtime.RawExtension, error) {
-	if spec == nil {
-		return &runtime.RawExtension{}, nil
+// convertLoadBalancersToMAPI mirrors the IBMPowerVSCluster's load balancers into MAPI
+// LoadBalancerReferences. LoadBalancers only apply to control-plane Machines; for worker
+// Machines we return a warning rather than silently dropping the configuration.
+func (m machineAndPowerVSMachineAndPowerVSCluster) convertLoadBalancersToMAPI() ([]mapiv1.LoadBalancerReference, []string) {
+	if len(m.powerVSCluster.Spec.LoadBalancers) == 0 {
+		return nil, nil
 	}
 
-	rawBytes, err := json.Marshal(spec)
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling providerSpec: %w", err)
+	if !m.isControlPlaneMachine() {
+		return nil, []string{"cannot convert LoadBalancers, LoadBalancers are not supported for worker Machines"}
 	}
 
-	return &runtime.RawExtension{
-		Raw: rawBytes,
-	}, nil
+	loadBalancers := make([]mapiv1.LoadBalancerReference, 0, len(m.powerVSCluster.Spec.LoadBalancers))
+
+	for _, lb := range m.powerVSCluster.Spec.LoadBalancers {
+		lbType := mapiv1.PowerVSLoadBalancerTypePrivate
+		if ptr.Deref(lb.Public, false) {
+			lbType = mapiv1.PowerVSLoadBalancerTypePublic
+		}
+
+		loadBalancers = append(loadBalancers, mapiv1.LoadBalancerReference{
+			Name: lb.Name,
+			Type: lbType,
+		})
+	}
+
+	return loadBalancers, nil
 }
 
+// Conversion helpers.
+
 func convertPowerVSNetworkToMAPI(fldPath *field.Path, network capibmv1.IBMPowerVSResourceReference) (mapiv1.PowerVSResource, *field.Error) {
 	var networkResource mapiv1.PowerVSResource
 	if network.ID != nil {
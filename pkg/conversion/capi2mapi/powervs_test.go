@@ -0,0 +1,137 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi2mapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capibmv1 "sigs.k8s.io/cluster-api-provider-ibmcloud/api/v1beta2"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	mapiv1 "github.com/openshift/api/machine/v1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/capi2mapi"
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/internal/conversion"
+)
+
+func TestCAPI2MAPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "capi2mapi Suite")
+}
+
+func newPowerVSMachine(labels map[string]string) (*capiv1.Machine, *capibmv1.IBMPowerVSMachine, *capibmv1.IBMPowerVSCluster) {
+	machine := &capiv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "powervs-machine", Labels: labels},
+	}
+
+	powerVSMachine := &capibmv1.IBMPowerVSMachine{
+		Spec: capibmv1.IBMPowerVSMachineSpec{
+			ServiceInstanceID: "crn:v1:bluemix:service-instance",
+			Network: capibmv1.IBMPowerVSResourceReference{
+				ID: ptr.To("network-id"),
+			},
+			Image: &capibmv1.IBMPowerVSResourceReference{
+				Name: ptr.To("image-name"),
+			},
+		},
+	}
+
+	powerVSCluster := &capibmv1.IBMPowerVSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "powervs-cluster"},
+	}
+
+	return machine, powerVSMachine, powerVSCluster
+}
+
+var _ = Describe("PowerVS LoadBalancer conversion", func() {
+	It("maps LoadBalancers onto a control plane Machine", func() {
+		machine, powerVSMachine, powerVSCluster := newPowerVSMachine(map[string]string{capiv1.MachineControlPlaneLabel: ""})
+		powerVSCluster.Spec.LoadBalancers = []capibmv1.VPCLoadBalancerSpec{
+			{Name: "my-lb", Public: ptr.To(true)},
+		}
+
+		mapiMachine, warnings, err := capi2mapi.FromMachineAndPowerVSMachineAndPowerVSCluster(machine, powerVSMachine, powerVSCluster).ToMachine()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		providerConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](mapiMachine.Spec.ProviderSpec.Value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerConfig.LoadBalancers).To(ConsistOf(mapiv1.LoadBalancerReference{
+			Name: "my-lb",
+			Type: mapiv1.PowerVSLoadBalancerTypePublic,
+		}))
+	})
+
+	It("warns instead of mapping LoadBalancers onto a worker Machine", func() {
+		machine, powerVSMachine, powerVSCluster := newPowerVSMachine(nil)
+		powerVSCluster.Spec.LoadBalancers = []capibmv1.VPCLoadBalancerSpec{
+			{Name: "my-lb", Public: ptr.To(true)},
+		}
+
+		mapiMachine, warnings, err := capi2mapi.FromMachineAndPowerVSMachineAndPowerVSCluster(machine, powerVSMachine, powerVSCluster).ToMachine()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("cannot convert LoadBalancers")))
+
+		providerConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](mapiMachine.Spec.ProviderSpec.Value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerConfig.LoadBalancers).To(BeEmpty())
+	})
+
+	It("leaves LoadBalancers empty when the IBMPowerVSCluster has none", func() {
+		machine, powerVSMachine, powerVSCluster := newPowerVSMachine(map[string]string{capiv1.MachineControlPlaneLabel: ""})
+
+		mapiMachine, warnings, err := capi2mapi.FromMachineAndPowerVSMachineAndPowerVSCluster(machine, powerVSMachine, powerVSCluster).ToMachine()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		providerConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](mapiMachine.Spec.ProviderSpec.Value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerConfig.LoadBalancers).To(BeEmpty())
+	})
+})
+
+var _ = Describe("PowerVS CredentialsSecret conversion", func() {
+	It("defaults CredentialsSecret to the well-known Secret name", func() {
+		machine, powerVSMachine, powerVSCluster := newPowerVSMachine(nil)
+
+		mapiMachine, _, err := capi2mapi.FromMachineAndPowerVSMachineAndPowerVSCluster(machine, powerVSMachine, powerVSCluster).ToMachine()
+		Expect(err).NotTo(HaveOccurred())
+
+		providerConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](mapiMachine.Spec.ProviderSpec.Value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerConfig.CredentialsSecret).To(Equal(&mapiv1.PowerVSSecretReference{Name: "powervs-credentials"}))
+	})
+
+	It("honours WithCredentialsSecretName", func() {
+		machine, powerVSMachine, powerVSCluster := newPowerVSMachine(nil)
+
+		mapiMachine, _, err := capi2mapi.FromMachineAndPowerVSMachineAndPowerVSCluster(
+			machine, powerVSMachine, powerVSCluster,
+			capi2mapi.WithCredentialsSecretName("custom-credentials"),
+		).ToMachine()
+		Expect(err).NotTo(HaveOccurred())
+
+		providerConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](mapiMachine.Spec.ProviderSpec.Value)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerConfig.CredentialsSecret).To(Equal(&mapiv1.PowerVSSecretReference{Name: "custom-credentials"}))
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi2mapi
+
+import (
+	mapiv1 "github.com/openshift/api/machine/v1"
+	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+)
+
+// ProviderConfigKind identifies the Kind recorded in the TypeMeta of a MAPI
+// Machine's Spec.ProviderSpec.Value.
+type ProviderConfigKind string
+
+const (
+	// AWSProviderConfigKind is the Kind used by AWSMachineProviderConfig.
+	AWSProviderConfigKind ProviderConfigKind = "AWSMachineProviderConfig"
+	// AzureProviderConfigKind is the Kind used by AzureMachineProviderSpec.
+	AzureProviderConfigKind ProviderConfigKind = "AzureMachineProviderSpec"
+	// GCPProviderConfigKind is the Kind used by GCPMachineProviderSpec.
+	GCPProviderConfigKind ProviderConfigKind = "GCPMachineProviderSpec"
+	// VSphereProviderConfigKind is the Kind used by VSphereMachineProviderSpec.
+	VSphereProviderConfigKind ProviderConfigKind = "VSphereMachineProviderSpec"
+	// PowerVSProviderConfigKind is the Kind used by PowerVSMachineProviderConfig.
+	PowerVSProviderConfigKind ProviderConfigKind = "PowerVSMachineProviderConfig"
+)
+
+// providerConfigTypes maps each ProviderConfigKind to a factory returning a new, empty instance
+// of the matching MAPI provider config type. This lets callers that only know the Kind string --
+// e.g. generic migration tooling built on top of this module -- decode a ProviderSpec.Value
+// without depending on a specific platform's conversion package.
+var providerConfigTypes = map[ProviderConfigKind]func() any{
+	AWSProviderConfigKind:     func() any { return &mapiv1beta1.AWSMachineProviderConfig{} },
+	AzureProviderConfigKind:   func() any { return &mapiv1beta1.AzureMachineProviderSpec{} },
+	GCPProviderConfigKind:     func() any { return &mapiv1beta1.GCPMachineProviderSpec{} },
+	VSphereProviderConfigKind: func() any { return &mapiv1beta1.VSphereMachineProviderSpec{} },
+	PowerVSProviderConfigKind: func() any { return &mapiv1.PowerVSMachineProviderConfig{} },
+}
+
+// NewProviderConfig returns a new, empty instance of the MAPI provider config type registered for
+// kind. It returns false if kind is not registered.
+func NewProviderConfig(kind ProviderConfigKind) (any, bool) {
+	factory, ok := providerConfigTypes[kind]
+	if !ok {
+		return nil, false
+	}
+
+	return factory(), true
+}
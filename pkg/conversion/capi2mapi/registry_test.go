@@ -0,0 +1,54 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capi2mapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mapiv1 "github.com/openshift/api/machine/v1"
+	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/capi2mapi"
+)
+
+var _ = Describe("NewProviderConfig", func() {
+	DescribeTable("returning the registered concrete type for a kind",
+		func(kind capi2mapi.ProviderConfigKind, expected any) {
+			config, ok := capi2mapi.NewProviderConfig(kind)
+			Expect(ok).To(BeTrue())
+			Expect(config).To(BeAssignableToTypeOf(expected))
+		},
+		Entry("AWS", capi2mapi.AWSProviderConfigKind, &mapiv1beta1.AWSMachineProviderConfig{}),
+		Entry("Azure", capi2mapi.AzureProviderConfigKind, &mapiv1beta1.AzureMachineProviderSpec{}),
+		Entry("GCP", capi2mapi.GCPProviderConfigKind, &mapiv1beta1.GCPMachineProviderSpec{}),
+		Entry("VSphere", capi2mapi.VSphereProviderConfigKind, &mapiv1beta1.VSphereMachineProviderSpec{}),
+		Entry("PowerVS", capi2mapi.PowerVSProviderConfigKind, &mapiv1.PowerVSMachineProviderConfig{}),
+	)
+
+	It("returns false for an unknown kind", func() {
+		config, ok := capi2mapi.NewProviderConfig(capi2mapi.ProviderConfigKind("UnknownProviderConfig"))
+		Expect(ok).To(BeFalse())
+		Expect(config).To(BeNil())
+	})
+
+	It("returns a fresh instance on each call", func() {
+		first, _ := capi2mapi.NewProviderConfig(capi2mapi.AWSProviderConfigKind)
+		second, _ := capi2mapi.NewProviderConfig(capi2mapi.AWSProviderConfigKind)
+		Expect(first).NotTo(BeIdenticalTo(second))
+	})
+})
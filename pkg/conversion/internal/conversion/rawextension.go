@@ -0,0 +1,59 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion holds helpers shared by the capi2mapi and mapi2capi conversions for
+// marshaling and unmarshaling MAPI provider specs.
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RawExtensionFromProviderSpec marshals a typed MAPI provider config into a runtime.RawExtension
+// suitable for storing in a Machine's Spec.ProviderSpec.Value.
+func RawExtensionFromProviderSpec[T any](spec *T) (*runtime.RawExtension, error) {
+	if spec == nil {
+		return &runtime.RawExtension{}, nil
+	}
+
+	rawBytes, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling providerSpec: %w", err)
+	}
+
+	return &runtime.RawExtension{
+		Raw: rawBytes,
+	}, nil
+}
+
+// ProviderSpecFromRawExtension unmarshals a Machine's Spec.ProviderSpec.Value into a typed MAPI
+// provider config. It returns a zero-value T when raw is nil or empty.
+func ProviderSpecFromRawExtension[T any](raw *runtime.RawExtension) (*T, error) {
+	spec := new(T)
+
+	if raw == nil || len(raw.Raw) == 0 {
+		return spec, nil
+	}
+
+	if err := json.Unmarshal(raw.Raw, spec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling providerSpec: %w", err)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapi2capi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+)
+
+// fromMAPIMachineToCAPIMachine translates the platform agnostic fields of a
+// MAPI Machine into a CAPI Machine. Provider specific fields, including the
+// bootstrap DataSecretName (which is read out of the provider-specific raw
+// ProviderSpec), are populated by the caller.
+func fromMAPIMachineToCAPIMachine(machine *mapiv1beta1.Machine, clusterName string) (*capiv1.Machine, error) {
+	capiMachine := &capiv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      machine.Name,
+			Namespace: machine.Namespace,
+			Labels:    machine.Labels,
+		},
+		Spec: capiv1.MachineSpec{
+			ClusterName: clusterName,
+			ProviderID:  machine.Spec.ProviderID,
+		},
+	}
+
+	return capiMachine, nil
+}
+
+// fromMAPIMachineSetToCAPIMachineSet translates the platform agnostic fields
+// of a MAPI MachineSet into a CAPI MachineSet. Provider specific fields are
+// populated by the caller.
+func fromMAPIMachineSetToCAPIMachineSet(machineSet *mapiv1beta1.MachineSet, clusterName string) (*capiv1.MachineSet, error) {
+	capiMachineSet := &capiv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      machineSet.Name,
+			Namespace: machineSet.Namespace,
+			Labels:    machineSet.Labels,
+		},
+		Spec: capiv1.MachineSetSpec{
+			ClusterName: clusterName,
+			Replicas:    machineSet.Spec.Replicas,
+		},
+	}
+
+	return capiMachineSet, nil
+}
+
+// utilErrorsAggregate aggregates a slice of errors into a single error,
+// returning nil if the slice is empty.
+func utilErrorsAggregate(errs []error) error {
+	return utilerrors.NewAggregate(errs)
+}
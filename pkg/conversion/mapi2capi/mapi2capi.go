@@ -0,0 +1,44 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mapi2capi converts OpenShift Machine API (MAPI) Machine/MachineSet
+// resources into their Cluster API (CAPI) equivalents. It is the mirror image
+// of the capi2mapi package.
+package mapi2capi
+
+import (
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MachineAndInfrastructureMachine is implemented by platform specific wrappers
+// that know how to convert a MAPI Machine into a CAPI Machine and its
+// corresponding infrastructure Machine.
+type MachineAndInfrastructureMachine interface {
+	// ToMachineAndInfrastructureMachine converts the wrapped MAPI Machine into
+	// a CAPI Machine and the platform specific infrastructure Machine.
+	ToMachineAndInfrastructureMachine() (*capiv1.Machine, client.Object, []string, error)
+}
+
+// MachineSetAndMachineTemplate is implemented by platform specific wrappers
+// that know how to convert a MAPI MachineSet into a CAPI MachineSet and its
+// corresponding infrastructure MachineTemplate.
+type MachineSetAndMachineTemplate interface {
+	// ToMachineSetAndMachineTemplate converts the wrapped MAPI MachineSet into
+	// a CAPI MachineSet and the platform specific infrastructure
+	// MachineTemplate.
+	ToMachineSetAndMachineTemplate() (*capiv1.MachineSet, client.Object, []string, error)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mapi2capi
+
+import (
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	capibmv1 "sigs.k8s.io/cluster-api-provider-ibmcloud/api/v1beta2"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mapiv1 "github.com/openshift/api/machine/v1"
+	mapiv1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/internal/conversion"
+)
+
+var (
+	errMAPIMachinePowerVSClusterCannotBeNil    = errors.New("provided Machine and IBMPowerVSCluster can not be nil")
+	errMAPIMachineSetPowerVSClusterCannotBeNil = errors.New("provided MachineSet and IBMPowerVSCluster can not be nil")
+)
+
+// powerVSMachineAndCluster stores the details of a MAPI Machine and the
+// IBMPowerVSCluster it is being converted against.
+type powerVSMachineAndCluster struct {
+	machine        *mapiv1beta1.Machine
+	powerVSCluster *capibmv1.IBMPowerVSCluster
+}
+
+// powerVSMachineSetAndCluster stores the details of a MAPI MachineSet and the
+// IBMPowerVSCluster it is being converted against.
+type powerVSMachineSetAndCluster struct {
+	machineSet     *mapiv1beta1.MachineSet
+	powerVSCluster *capibmv1.IBMPowerVSCluster
+}
+
+// FromPowerVSMachineAndInfra wraps a MAPI Machine and a CAPIBM IBMPowerVSCluster
+// into a mapi2capi MachineAndInfrastructureMachine.
+func FromPowerVSMachineAndInfra(m *mapiv1beta1.Machine, cluster *capibmv1.IBMPowerVSCluster) MachineAndInfrastructureMachine {
+	return &powerVSMachineAndCluster{machine: m, powerVSCluster: cluster}
+}
+
+// FromPowerVSMachineSetAndInfra wraps a MAPI MachineSet and a CAPIBM
+// IBMPowerVSCluster into a mapi2capi MachineSetAndMachineTemplate.
+func FromPowerVSMachineSetAndInfra(ms *mapiv1beta1.MachineSet, cluster *capibmv1.IBMPowerVSCluster) MachineSetAndMachineTemplate {
+	return &powerVSMachineSetAndCluster{machineSet: ms, powerVSCluster: cluster}
+}
+
+// ToMachineAndInfrastructureMachine converts a mapi2capi powerVSMachineAndCluster into a
+// CAPI Machine and IBMPowerVSMachine.
+func (m powerVSMachineAndCluster) ToMachineAndInfrastructureMachine() (*capiv1.Machine, client.Object, []string, error) {
+	if m.machine == nil || m.powerVSCluster == nil {
+		return nil, nil, nil, errMAPIMachinePowerVSClusterCannotBeNil
+	}
+
+	var (
+		errs     []error
+		warnings []string
+	)
+
+	powerVSProviderConfig, err := conversion.ProviderSpecFromRawExtension[mapiv1.PowerVSMachineProviderConfig](m.machine.Spec.ProviderSpec.Value)
+	if err != nil {
+		errs = append(errs, err)
+		powerVSProviderConfig = &mapiv1.PowerVSMachineProviderConfig{}
+	}
+
+	powerVSMachineSpec, warn, err := m.toPowerVSMachineSpec(powerVSProviderConfig)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	warnings = append(warnings, warn...)
+
+	capiMachine, err := fromMAPIMachineToCAPIMachine(m.machine, m.powerVSCluster.Name)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if powerVSProviderConfig.UserDataSecret != nil {
+		capiMachine.Spec.Bootstrap.DataSecretName = &powerVSProviderConfig.UserDataSecret.Name
+	}
+
+	powerVSMachine := &capibmv1.IBMPowerVSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.machine.Name,
+			Namespace: m.machine.Namespace,
+			Labels:    clusterLabels(m.powerVSCluster.Name),
+		},
+		Spec: powerVSMachineSpec,
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, warnings, utilErrorsAggregate(errs)
+	}
+
+	return capiMachine, powerVSMachine, warnings, nil
+}
+
+// ToMachineSetAndMachineTemplate converts a mapi2capi powerVSMachineSetAndCluster
+// into a CAPI MachineSet and IBMPowerVSMachineTemplate.
+func (m powerVSMachineSetAndCluster) ToMachineSetAndMachineTemplate() (*capiv1.MachineSet, client.Object, []string, error) {
+	if m.machineSet == nil || m.powerVSCluster == nil {
+		return nil, nil, nil, errMAPIMachineSetPowerVSClusterCannotBeNil
+	}
+
+	var (
+		errs     []error
+		warnings []string
+	)
+
+	// Run the single Machine conversion against the MachineSet's template so
+	// that we reuse the same field translation and error reporting.
+	templateMachine := &mapiv1beta1.Machine{
+		ObjectMeta: m.machineSet.Spec.Template.ObjectMeta,
+		Spec:       m.machineSet.Spec.Template.Spec,
+	}
+
+	capiMachine, powerVSMachineObj, warn, err := (&powerVSMachineAndCluster{machine: templateMachine, powerVSCluster: m.powerVSCluster}).ToMachineAndInfrastructureMachine()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	warnings = append(warnings, warn...)
+
+	capiMachineSet, err := fromMAPIMachineSetToCAPIMachineSet(m.machineSet, m.powerVSCluster.Name)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, warnings, utilErrorsAggregate(errs)
+	}
+
+	powerVSMachine, ok := powerVSMachineObj.(*capibmv1.IBMPowerVSMachine)
+	if !ok {
+		return nil, nil, warnings, errors.New("unable to convert infrastructure machine to IBMPowerVSMachine")
+	}
+
+	capiMachineSet.Spec.Template.Spec = capiMachine.Spec
+	capiMachineSet.Spec.Template.ObjectMeta.Labels = capiMachine.Labels
+	capiMachineSet.Spec.Template.ObjectMeta.Annotations = capiMachine.Annotations
+
+	template := &capibmv1.IBMPowerVSMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.machineSet.Name,
+			Namespace: m.machineSet.Namespace,
+		},
+		Spec: capibmv1.IBMPowerVSMachineTemplateSpec{
+			Template: capibmv1.IBMPowerVSMachineTemplateResource{
+				Spec: powerVSMachine.Spec,
+			},
+		},
+	}
+
+	return capiMachineSet, template, warnings, nil
+}
+
+// toPowerVSMachineSpec converts a MAPI PowerVSMachineProviderConfig into a
+// CAPIBM IBMPowerVSMachineSpec.
+//
+//nolint:funlen
+func (m powerVSMachineAndCluster) toPowerVSMachineSpec(providerConfig *mapiv1.PowerVSMachineProviderConfig) (capibmv1.IBMPowerVSMachineSpec, []string, error) {
+	var (
+		warnings []string
+		errs     field.ErrorList
+	)
+
+	fldPath := field.NewPath("spec", "providerSpec", "value")
+
+	serviceInstanceID, serviceInstance, err := convertPowerVSServiceInstanceToCAPI(fldPath.Child("serviceInstance"), providerConfig.ServiceInstance)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	image, err := convertPowerVSImageToCAPI(fldPath.Child("image"), providerConfig.Image)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	network, err := convertPowerVSNetworkToCAPI(fldPath.Child("network"), providerConfig.Network)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	spec := capibmv1.IBMPowerVSMachineSpec{
+		ServiceInstanceID: serviceInstanceID,
+		ServiceInstance:   serviceInstance,
+		Image:             image,
+		Network:           network,
+		SSHKey:            providerConfig.KeyPairName,
+		SystemType:        providerConfig.SystemType,
+		ProcessorType:     capibmv1.PowerVSProcessorType(providerConfig.ProcessorType),
+		Processors:        providerConfig.Processors,
+		MemoryGiB:         providerConfig.MemoryGiB,
+	}
+
+	if len(errs) > 0 {
+		return spec, warnings, errs.ToAggregate()
+	}
+
+	return spec, warnings, nil
+}
+
+// Conversion helpers.
+
+func convertPowerVSNetworkToCAPI(fldPath *field.Path, network mapiv1.PowerVSResource) (capibmv1.IBMPowerVSResourceReference, *field.Error) {
+	var networkResource capibmv1.IBMPowerVSResourceReference
+
+	switch network.Type {
+	case mapiv1.PowerVSResourceTypeID:
+		networkResource.ID = network.ID
+	case mapiv1.PowerVSResourceTypeName:
+		networkResource.Name = network.Name
+	case mapiv1.PowerVSResourceTypeRegEx:
+		networkResource.RegEx = network.RegEx
+	default:
+		return networkResource, field.Invalid(fldPath, network, "unable to convert network to CAPI")
+	}
+
+	return networkResource, nil
+}
+
+// convertPowerVSImageToCAPI always reconstructs the typed Image reference, never ImageRef.
+// MAPI's PowerVSResource has no record of whether it originated from IBMPowerVSMachineSpec's
+// Image or its ImageRef fallback (convertPowerVSImageToMAPI collapses ImageRef into a Name-typed
+// PowerVSResource indistinguishable from a Name-typed Image), so a Machine that was converted from
+// an ImageRef-only CAPI Machine round-trips back with Image populated instead of ImageRef.
+func convertPowerVSImageToCAPI(fldPath *field.Path, image mapiv1.PowerVSResource) (*capibmv1.IBMPowerVSResourceReference, *field.Error) {
+	imageResource := &capibmv1.IBMPowerVSResourceReference{}
+
+	switch image.Type {
+	case mapiv1.PowerVSResourceTypeID:
+		imageResource.ID = image.ID
+	case mapiv1.PowerVSResourceTypeName:
+		imageResource.Name = image.Name
+	case mapiv1.PowerVSResourceTypeRegEx:
+		imageResource.RegEx = image.RegEx
+	default:
+		return nil, field.Invalid(fldPath, image, "unable to convert image to CAPI")
+	}
+
+	return imageResource, nil
+}
+
+// convertPowerVSServiceInstanceToCAPI mirrors convertPowerVSServiceInstanceToMAPI's preference for the
+// legacy ServiceInstanceID string field on ID-typed references, so that a Machine which only set
+// ServiceInstanceID (and left ServiceInstance nil) round-trips back to that same shape rather than
+// gaining a populated ServiceInstance pointer.
+func convertPowerVSServiceInstanceToCAPI(fldPath *field.Path, serviceInstance mapiv1.PowerVSResource) (string, *capibmv1.IBMPowerVSResourceReference, *field.Error) {
+	switch serviceInstance.Type {
+	case mapiv1.PowerVSResourceTypeID:
+		return ptr.Deref(serviceInstance.ID, ""), nil, nil
+	case mapiv1.PowerVSResourceTypeName:
+		return "", &capibmv1.IBMPowerVSResourceReference{Name: serviceInstance.Name}, nil
+	case mapiv1.PowerVSResourceTypeRegEx:
+		return "", &capibmv1.IBMPowerVSResourceReference{RegEx: serviceInstance.RegEx}, nil
+	default:
+		return "", nil, field.Invalid(fldPath, serviceInstance, "unable to convert service instance to CAPI")
+	}
+}
+
+// clusterLabels returns the standard CAPI cluster-name label set applied to
+// every infrastructure resource produced for a given cluster.
+func clusterLabels(clusterName string) map[string]string {
+	return map[string]string{
+		capiv1.ClusterNameLabel: clusterName,
+	}
+}
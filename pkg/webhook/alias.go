@@ -0,0 +1,43 @@
+/*
+Copyright 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook re-exports the provider validation webhooks implemented in
+// internal/webhooks/providers for external consumers. The implementation lives
+// in the internal package so it can be wired up from a single AddToManager
+// entry point; this package only exists to keep existing import paths working.
+package webhook
+
+import (
+	"github.com/openshift/cluster-capi-operator/internal/webhooks/providers"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// CoreProviderWebhook validates v1alpha1.CoreProvider resources.
+type CoreProviderWebhook = providers.CoreProviderWebhook
+
+// InfrastructureProviderWebhook validates v1alpha1.InfrastructureProvider resources.
+type InfrastructureProviderWebhook = providers.InfrastructureProviderWebhook
+
+// BootstrapProviderWebhook validates v1alpha1.BootstrapProvider resources.
+type BootstrapProviderWebhook = providers.BootstrapProviderWebhook
+
+// ControlPlaneProviderWebhook validates v1alpha1.ControlPlaneProvider resources.
+type ControlPlaneProviderWebhook = providers.ControlPlaneProviderWebhook
+
+// AddToManager registers all provider validation webhooks with the manager.
+func AddToManager(mgr ctrl.Manager) error {
+	return providers.AddToManager(mgr)
+}